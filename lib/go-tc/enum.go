@@ -31,6 +31,7 @@ package tc
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 )
@@ -98,6 +99,18 @@ func CacheTypeFromString(s string) CacheType {
 	return CacheTypeInvalid
 }
 
+// ToTopologyTier converts this CacheType to its corresponding TopologyCacheTier, for code that needs to reason about a cache's position in terms of the more flexible multi-tier enum rather than the legacy EDGE/MID dichotomy. CacheTypeInvalid converts to TopologyCacheTierInvalid.
+func (t CacheType) ToTopologyTier() TopologyCacheTier {
+	switch t {
+	case CacheTypeEdge:
+		return TopologyCacheTierEdge
+	case CacheTypeMid:
+		return TopologyCacheTierMid
+	default:
+		return TopologyCacheTierInvalid
+	}
+}
+
 // DSTypeCategory is the Delivery Service type category: HTTP or DNS
 type DSTypeCategory string
 
@@ -190,24 +203,31 @@ func CacheStatusFromString(s string) CacheStatus {
 type DeepCachingType string
 
 const (
-	DeepCachingTypeNever   = DeepCachingType("") // default value
-	DeepCachingTypeAlways  = DeepCachingType("ALWAYS")
+	// DeepCachingTypeUnset indicates the field was not explicitly set. This is the default value for a DeepCachingType, and is distinct from DeepCachingTypeNever so that PATCH-style updates can tell "omitted" apart from "explicitly NEVER".
+	DeepCachingTypeUnset = DeepCachingType("")
+	// DeepCachingTypeNever explicitly disables deep caching.
+	DeepCachingTypeNever = DeepCachingType("NEVER")
+	// DeepCachingTypeAlways explicitly enables deep caching.
+	DeepCachingTypeAlways = DeepCachingType("ALWAYS")
+	// DeepCachingTypeInvalid represents an invalid deep caching type enumeration.
 	DeepCachingTypeInvalid = DeepCachingType("INVALID")
 )
 
-// String returns a string representation of this deep caching type
+// String returns a string representation of this deep caching type.
 func (t DeepCachingType) String() string {
 	switch t {
 	case DeepCachingTypeAlways:
-		return string(t)
+		fallthrough
 	case DeepCachingTypeNever:
-		return "NEVER"
+		return string(t)
+	case DeepCachingTypeUnset:
+		return "UNSET"
 	default:
 		return "INVALID"
 	}
 }
 
-// DeepCachingTypeFromString returns a DeepCachingType from its string representation, or DeepCachingTypeInvalid if the string is not a valid type.
+// DeepCachingTypeFromString returns a DeepCachingType from its string representation, or DeepCachingTypeInvalid if the string is not a valid type. An empty string returns DeepCachingTypeUnset rather than defaulting to DeepCachingTypeNever, so callers can tell an omitted value from an explicit one; use Coalesce to fall back to the old always-defaulting behavior.
 func DeepCachingTypeFromString(s string) DeepCachingType {
 	switch strings.ToLower(s) {
 	case "always":
@@ -215,17 +235,24 @@ func DeepCachingTypeFromString(s string) DeepCachingType {
 	case "never":
 		return DeepCachingTypeNever
 	case "":
-		// default when omitted
-		return DeepCachingTypeNever
+		return DeepCachingTypeUnset
 	default:
 		return DeepCachingTypeInvalid
 	}
 }
 
-// UnmarshalJSON unmarshals a JSON representation of a DeepCachingType (i.e. a string) or returns an error if the DeepCachingType is invalid
+// Coalesce returns this DeepCachingType if it is explicitly set, or the given default if it is DeepCachingTypeUnset. Callers that don't need to distinguish "omitted" from "explicitly NEVER" can use this to get the old always-defaulting behavior.
+func (t DeepCachingType) Coalesce(def DeepCachingType) DeepCachingType {
+	if t == DeepCachingTypeUnset {
+		return def
+	}
+	return t
+}
+
+// UnmarshalJSON unmarshals a JSON representation of a DeepCachingType (i.e. a string), or returns an error if the DeepCachingType is invalid. A JSON null unmarshals to DeepCachingTypeUnset, distinct from an explicit "NEVER".
 func (t *DeepCachingType) UnmarshalJSON(data []byte) error {
 	if string(data) == "null" {
-		*t = DeepCachingTypeNever
+		*t = DeepCachingTypeUnset
 		return nil
 	}
 	s, err := strconv.Unquote(string(data))
@@ -239,8 +266,11 @@ func (t *DeepCachingType) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// MarshalJSON marshals into a JSON representation
+// MarshalJSON marshals into a JSON representation. DeepCachingTypeUnset marshals to null, so that an omitted field round-trips instead of becoming indistinguishable from an explicit NEVER.
 func (t DeepCachingType) MarshalJSON() ([]byte, error) {
+	if t == DeepCachingTypeUnset {
+		return json.Marshal(nil)
+	}
 	return json.Marshal(t.String())
 }
 
@@ -348,19 +378,27 @@ func (t DSType) IsDNS() bool {
 	return false
 }
 
-// IsSteering returns whether the DSType is a Steering category
+// IsSteering returns whether the DSType is a Steering category.
 func (t DSType) IsSteering() bool {
 	switch t {
 	case DSTypeSteering:
 		fallthrough
 	case DSTypeClientSteering:
-		fallthrough
-	case DSTypeDNSLive:
 		return true
 	}
 	return false
 }
 
+// IsClientSteering returns whether the DSType is specifically CLIENT_STEERING, as opposed to ordinary STEERING.
+func (t DSType) IsClientSteering() bool {
+	return t == DSTypeClientSteering
+}
+
+// RequiresTargets returns whether Delivery Services of this type must have at least one steering target configured.
+func (t DSType) RequiresTargets() bool {
+	return t.IsSteering()
+}
+
 // HasSSLKeys returns whether delivery services of this type have SSL keys.
 func (t DSType) HasSSLKeys() bool {
 	return t.IsHTTP() || t.IsDNS() || t.IsSteering()
@@ -381,6 +419,11 @@ func (t DSType) IsLive() bool {
 	return false
 }
 
+// SupportsTLSVersions returns whether Delivery Services of this type support restricting the set of TLS versions a client may use. Only HTTP- and DNS-category types do; ANY_MAP and Steering types do not.
+func (t DSType) SupportsTLSVersions() bool {
+	return t.IsHTTP() || t.IsDNS()
+}
+
 // IsLive returns whether delivery services of this type are "national".
 func (t DSType) IsNational() bool {
 	switch t {
@@ -392,6 +435,485 @@ func (t DSType) IsNational() bool {
 	return false
 }
 
+// TLSVersion represents a TLS protocol version that may be explicitly allowed or disallowed for a Delivery Service.
+type TLSVersion string
+
+const (
+	// TLSVersion10 represents TLS version 1.0.
+	TLSVersion10 = TLSVersion("TLSv1.0")
+	// TLSVersion11 represents TLS version 1.1.
+	TLSVersion11 = TLSVersion("TLSv1.1")
+	// TLSVersion12 represents TLS version 1.2.
+	TLSVersion12 = TLSVersion("TLSv1.2")
+	// TLSVersion13 represents TLS version 1.3.
+	TLSVersion13 = TLSVersion("TLSv1.3")
+	// TLSVersionInvalid represents an invalid TLS version enumeration. Note this is the default construction for a TLSVersion.
+	TLSVersionInvalid = TLSVersion("")
+)
+
+// String returns a string representation of this TLS version.
+func (v TLSVersion) String() string {
+	switch v {
+	case TLSVersion10:
+		fallthrough
+	case TLSVersion11:
+		fallthrough
+	case TLSVersion12:
+		fallthrough
+	case TLSVersion13:
+		return string(v)
+	default:
+		return "INVALID"
+	}
+}
+
+// TLSVersionFromString returns a TLSVersion from its string representation, or TLSVersionInvalid if the string is not a valid version. It tolerates the variety of forms TLS versions tend to show up in (e.g. "1.2", "TLSv1.2", "tls_1_2", "TLS 1.2" all resolve to TLSVersion12).
+func TLSVersionFromString(s string) TLSVersion {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.ReplaceAll(s, "_", ".")
+	s = strings.ReplaceAll(s, "-", ".")
+	s = strings.ReplaceAll(s, " ", "")
+	s = strings.TrimPrefix(s, "tlsv")
+	s = strings.TrimPrefix(s, "tls")
+	s = strings.TrimPrefix(s, ".")
+	switch s {
+	case "1.0":
+		return TLSVersion10
+	case "1.1":
+		return TLSVersion11
+	case "1.2":
+		return TLSVersion12
+	case "1.3":
+		return TLSVersion13
+	default:
+		return TLSVersionInvalid
+	}
+}
+
+// UnmarshalJSON unmarshals a JSON representation of a TLSVersion (i.e. a string) or returns an error if the TLSVersion is invalid.
+func (v *TLSVersion) UnmarshalJSON(data []byte) error {
+	s, err := strconv.Unquote(string(data))
+	if err != nil {
+		return errors.New(string(data) + " JSON not quoted")
+	}
+	*v = TLSVersionFromString(s)
+	if *v == TLSVersionInvalid {
+		return errors.New(string(data) + " is not a TLSVersion")
+	}
+	return nil
+}
+
+// MarshalJSON marshals into a JSON representation, or returns an error if the TLSVersion is invalid - consistent with the other zero-value-is-invalid enums in this package (e.g. DSProtocol), rather than silently emitting "INVALID".
+func (v TLSVersion) MarshalJSON() ([]byte, error) {
+	if v == TLSVersionInvalid {
+		return nil, errors.New("invalid TLSVersion")
+	}
+	return json.Marshal(v.String())
+}
+
+// TLSVersionsWarnings examines a set of TLS versions allowed by a Delivery Service and returns human-readable warnings about insecure or inconsistent combinations. It does not reject anything outright; it's meant to be surfaced to an operator configuring the Delivery Service.
+func TLSVersionsWarnings(versions []TLSVersion) []string {
+	warnings := []string{}
+	allowed := make(map[TLSVersion]bool, len(versions))
+	for _, v := range versions {
+		allowed[v] = true
+	}
+
+	if allowed[TLSVersion10] {
+		warnings = append(warnings, "TLS 1.0 is insecure and should not be allowed")
+	}
+	if allowed[TLSVersion11] {
+		warnings = append(warnings, "TLS 1.1 is insecure and should not be allowed")
+	}
+	if allowed[TLSVersion10] && allowed[TLSVersion12] && !allowed[TLSVersion11] {
+		warnings = append(warnings, "allowing TLS 1.0 and TLS 1.2 without TLS 1.1 leaves a gap that may break clients which only negotiate the missing version")
+	}
+	if allowed[TLSVersion12] && !allowed[TLSVersion13] {
+		warnings = append(warnings, "TLS 1.2 is allowed but TLS 1.3 is not; consider allowing TLS 1.3 for improved security and performance")
+	}
+	return warnings
+}
+
+// DSProtocol represents the protocol(s) a Delivery Service accepts requests over. Per this package's enum convention, DSProtocolInvalid is the zero value so a default-initialized or JSON-omitted DSProtocol reads as invalid rather than silently as DSProtocolHTTP; dsProtocolWireValues maps the non-zero internal values to the integers Traffic Ops has historically used on the wire.
+type DSProtocol int
+
+const (
+	// DSProtocolInvalid represents an invalid Delivery Service protocol enumeration. Note this is the default construction for a DSProtocol.
+	DSProtocolInvalid = DSProtocol(iota)
+	// DSProtocolHTTP serves HTTP only.
+	DSProtocolHTTP
+	// DSProtocolHTTPS serves HTTPS only.
+	DSProtocolHTTPS
+	// DSProtocolHTTPAndHTTPS serves both HTTP and HTTPS.
+	DSProtocolHTTPAndHTTPS
+	// DSProtocolHTTPToHTTPS serves HTTP requests by redirecting them to HTTPS.
+	DSProtocolHTTPToHTTPS
+)
+
+// dsProtocolWireValues maps each valid DSProtocol to the integer Traffic Ops uses on the wire (HTTP=0, HTTPS=1, HTTP_AND_HTTPS=2, HTTP_TO_HTTPS=3).
+var dsProtocolWireValues = map[DSProtocol]int{
+	DSProtocolHTTP:         0,
+	DSProtocolHTTPS:        1,
+	DSProtocolHTTPAndHTTPS: 2,
+	DSProtocolHTTPToHTTPS:  3,
+}
+
+// dsProtocolFromWireValue is the inverse of dsProtocolWireValues.
+var dsProtocolFromWireValue = map[int]DSProtocol{
+	0: DSProtocolHTTP,
+	1: DSProtocolHTTPS,
+	2: DSProtocolHTTPAndHTTPS,
+	3: DSProtocolHTTPToHTTPS,
+}
+
+// String returns a string representation of this Delivery Service protocol.
+func (p DSProtocol) String() string {
+	switch p {
+	case DSProtocolHTTP:
+		return "HTTP"
+	case DSProtocolHTTPS:
+		return "HTTPS"
+	case DSProtocolHTTPAndHTTPS:
+		return "HTTP_AND_HTTPS"
+	case DSProtocolHTTPToHTTPS:
+		return "HTTP_TO_HTTPS"
+	default:
+		return "INVALID"
+	}
+}
+
+// DSProtocolFromString returns a DSProtocol from its string representation, or DSProtocolInvalid if the string is not a valid protocol.
+func DSProtocolFromString(s string) DSProtocol {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "HTTP":
+		return DSProtocolHTTP
+	case "HTTPS":
+		return DSProtocolHTTPS
+	case "HTTP_AND_HTTPS":
+		return DSProtocolHTTPAndHTTPS
+	case "HTTP_TO_HTTPS":
+		return DSProtocolHTTPToHTTPS
+	default:
+		return DSProtocolInvalid
+	}
+}
+
+// DSProtocolFromInt returns a DSProtocol from its Traffic Ops wire integer representation, or DSProtocolInvalid if the integer is not a valid protocol.
+func DSProtocolFromInt(i int) DSProtocol {
+	if p, ok := dsProtocolFromWireValue[i]; ok {
+		return p
+	}
+	return DSProtocolInvalid
+}
+
+// AllowsHTTP returns whether this protocol accepts plain HTTP requests, even if only to redirect them to HTTPS.
+func (p DSProtocol) AllowsHTTP() bool {
+	switch p {
+	case DSProtocolHTTP, DSProtocolHTTPAndHTTPS, DSProtocolHTTPToHTTPS:
+		return true
+	}
+	return false
+}
+
+// AllowsHTTPS returns whether this protocol accepts HTTPS requests.
+func (p DSProtocol) AllowsHTTPS() bool {
+	switch p {
+	case DSProtocolHTTPS, DSProtocolHTTPAndHTTPS, DSProtocolHTTPToHTTPS:
+		return true
+	}
+	return false
+}
+
+// RedirectsToHTTPS returns whether this protocol redirects HTTP requests to HTTPS rather than serving them directly.
+func (p DSProtocol) RedirectsToHTTPS() bool {
+	return p == DSProtocolHTTPToHTTPS
+}
+
+// RequiresSSLKeys returns whether a Delivery Service using this protocol requires SSL keys to be provisioned.
+func (p DSProtocol) RequiresSSLKeys() bool {
+	return p.AllowsHTTPS()
+}
+
+// MarshalJSON marshals into a JSON representation. Protocols are marshaled as their Traffic Ops wire integer form, matching the existing API contract.
+func (p DSProtocol) MarshalJSON() ([]byte, error) {
+	wire, ok := dsProtocolWireValues[p]
+	if !ok {
+		return nil, errors.New("invalid DSProtocol")
+	}
+	return json.Marshal(wire)
+}
+
+// UnmarshalJSON unmarshals a JSON representation of a DSProtocol, accepting either its integer form (e.g. 1) or its string form (e.g. "HTTPS"), or returns an error if the DSProtocol is invalid.
+func (p *DSProtocol) UnmarshalJSON(data []byte) error {
+	var i int
+	if err := json.Unmarshal(data, &i); err == nil {
+		*p = DSProtocolFromInt(i)
+	} else {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return errors.New(string(data) + " is not a valid DSProtocol")
+		}
+		*p = DSProtocolFromString(s)
+	}
+	if *p == DSProtocolInvalid {
+		return errors.New(string(data) + " is not a DSProtocol")
+	}
+	return nil
+}
+
+// NeedsCertificates returns whether a Delivery Service of the given type and protocol requires SSL certificates to be provisioned. This combines DSType.HasSSLKeys() with DSProtocol.RequiresSSLKeys(), since neither alone is sufficient: an ANY_MAP Delivery Service configured for HTTPS still has no certificate to manage, and an HTTP-category Delivery Service configured for plain HTTP needs none either.
+func NeedsCertificates(t DSType, p DSProtocol) bool {
+	return t.HasSSLKeys() && p.RequiresSSLKeys()
+}
+
+// RangeRequestHandling represents how a Delivery Service's cache tier handles HTTP Range requests. RangeRequestHandlingInvalid is the zero value, per this package's enum convention, so a default-initialized or JSON-omitted RangeRequestHandling reads as invalid rather than silently as RangeRequestHandlingNone; rangeRequestHandlingWireValues maps the other, real values to the integers Traffic Ops uses on the wire, which are unaffected by where Invalid falls internally.
+type RangeRequestHandling int
+
+const (
+	// RangeRequestHandlingInvalid represents an invalid range request handling enumeration. Note this is the default construction for a RangeRequestHandling.
+	RangeRequestHandlingInvalid = RangeRequestHandling(iota)
+	// RangeRequestHandlingNone disables any special range request handling; ranges are passed through to the Origin on every request.
+	RangeRequestHandlingNone
+	// RangeRequestHandlingBackgroundFetch fetches the full object from the Origin in the background and serves the requested range from cache once it's available.
+	RangeRequestHandlingBackgroundFetch
+	// RangeRequestHandlingCacheRangeRequests caches each distinct byte range as its own cached object.
+	RangeRequestHandlingCacheRangeRequests
+	// RangeRequestHandlingSlice serves ranges by fetching and caching fixed-size slices of the object, aligned to a SliceBlockSize.
+	RangeRequestHandlingSlice
+)
+
+// rangeRequestHandlingWireValues maps each valid RangeRequestHandling to the integer Traffic Ops uses on the wire (NONE=0, BACKGROUND_FETCH=1, CACHE_RANGE_REQUESTS=2, SLICE=3), matching the existing API contract regardless of how Invalid is represented internally.
+var rangeRequestHandlingWireValues = map[RangeRequestHandling]int{
+	RangeRequestHandlingNone:               0,
+	RangeRequestHandlingBackgroundFetch:    1,
+	RangeRequestHandlingCacheRangeRequests: 2,
+	RangeRequestHandlingSlice:              3,
+}
+
+// rangeRequestHandlingFromWireValue is the inverse of rangeRequestHandlingWireValues.
+var rangeRequestHandlingFromWireValue = map[int]RangeRequestHandling{
+	0: RangeRequestHandlingNone,
+	1: RangeRequestHandlingBackgroundFetch,
+	2: RangeRequestHandlingCacheRangeRequests,
+	3: RangeRequestHandlingSlice,
+}
+
+// String returns a string representation of this range request handling.
+func (r RangeRequestHandling) String() string {
+	switch r {
+	case RangeRequestHandlingNone:
+		return "NONE"
+	case RangeRequestHandlingBackgroundFetch:
+		return "BACKGROUND_FETCH"
+	case RangeRequestHandlingCacheRangeRequests:
+		return "CACHE_RANGE_REQUESTS"
+	case RangeRequestHandlingSlice:
+		return "SLICE"
+	default:
+		return "INVALID"
+	}
+}
+
+// IsValid returns whether this is one of the defined RangeRequestHandling values.
+func (r RangeRequestHandling) IsValid() bool {
+	switch r {
+	case RangeRequestHandlingNone, RangeRequestHandlingBackgroundFetch, RangeRequestHandlingCacheRangeRequests, RangeRequestHandlingSlice:
+		return true
+	default:
+		return false
+	}
+}
+
+// RangeRequestHandlingFromString returns a RangeRequestHandling from its string representation, or RangeRequestHandlingInvalid if the string is not a valid value.
+func RangeRequestHandlingFromString(s string) RangeRequestHandling {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "NONE":
+		return RangeRequestHandlingNone
+	case "BACKGROUND_FETCH":
+		return RangeRequestHandlingBackgroundFetch
+	case "CACHE_RANGE_REQUESTS":
+		return RangeRequestHandlingCacheRangeRequests
+	case "SLICE":
+		return RangeRequestHandlingSlice
+	default:
+		return RangeRequestHandlingInvalid
+	}
+}
+
+// RangeRequestHandlingFromInt returns a RangeRequestHandling from its Traffic Ops wire integer representation, or RangeRequestHandlingInvalid if the integer is not a valid value.
+func RangeRequestHandlingFromInt(i int) RangeRequestHandling {
+	if r, ok := rangeRequestHandlingFromWireValue[i]; ok {
+		return r
+	}
+	return RangeRequestHandlingInvalid
+}
+
+// MarshalJSON marshals into a JSON representation. Range request handling is marshaled as its Traffic Ops wire integer form, matching the existing API contract.
+func (r RangeRequestHandling) MarshalJSON() ([]byte, error) {
+	wire, ok := rangeRequestHandlingWireValues[r]
+	if !ok {
+		return nil, errors.New("invalid RangeRequestHandling")
+	}
+	return json.Marshal(wire)
+}
+
+// UnmarshalJSON unmarshals a JSON representation of a RangeRequestHandling, accepting either its integer form (e.g. 3) or its string form (e.g. "SLICE"), or returns an error if the value is invalid.
+func (r *RangeRequestHandling) UnmarshalJSON(data []byte) error {
+	var i int
+	if err := json.Unmarshal(data, &i); err == nil {
+		*r = RangeRequestHandlingFromInt(i)
+	} else {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return errors.New(string(data) + " is not a valid RangeRequestHandling")
+		}
+		*r = RangeRequestHandlingFromString(s)
+	}
+	if !r.IsValid() {
+		return errors.New(string(data) + " is not a RangeRequestHandling")
+	}
+	return nil
+}
+
+// SliceBlockSize is the fixed byte size of each cached slice when a Delivery Service uses RangeRequestHandlingSlice.
+type SliceBlockSize int
+
+const (
+	// MinSliceBlockSize is the smallest block size Traffic Server supports for slice caching (256KiB).
+	MinSliceBlockSize = SliceBlockSize(262144)
+	// MaxSliceBlockSize is the largest block size Traffic Server supports for slice caching (32MiB).
+	MaxSliceBlockSize = SliceBlockSize(33554432)
+)
+
+// Validate returns an error if this SliceBlockSize is not usable with RangeRequestHandlingSlice: it must be a power of two between MinSliceBlockSize and MaxSliceBlockSize, inclusive.
+func (s SliceBlockSize) Validate() error {
+	if s < MinSliceBlockSize || s > MaxSliceBlockSize {
+		return fmt.Errorf("slice block size must be between %d and %d bytes, got %d", MinSliceBlockSize, MaxSliceBlockSize, s)
+	}
+	if s&(s-1) != 0 {
+		return fmt.Errorf("slice block size must be a power of two, got %d", s)
+	}
+	return nil
+}
+
+// QStringHandling represents how a Delivery Service's cache tier handles query strings when computing cache keys and forwarding requests to the Origin. QStringHandlingInvalid is the zero value, per this package's enum convention, so a default-initialized or JSON-omitted QStringHandling reads as invalid rather than silently as QStringHandlingUse; qStringHandlingWireValues maps the other, real values to the integers Traffic Ops uses on the wire, which are unaffected by where Invalid falls internally.
+type QStringHandling int
+
+const (
+	// QStringHandlingInvalid represents an invalid query string handling enumeration. Note this is the default construction for a QStringHandling.
+	QStringHandlingInvalid = QStringHandling(iota)
+	// QStringHandlingUse includes the query string in the cache key and forwards it to the Origin.
+	QStringHandlingUse
+	// QStringHandlingIgnore forwards the query string to the Origin but ignores it when computing the cache key.
+	QStringHandlingIgnore
+	// QStringHandlingDrop strips the query string entirely before forwarding to the Origin or caching.
+	QStringHandlingDrop
+)
+
+// qStringHandlingWireValues maps each valid QStringHandling to the integer Traffic Ops uses on the wire (USE=0, IGNORE=1, DROP=2), matching the existing API contract regardless of how Invalid is represented internally.
+var qStringHandlingWireValues = map[QStringHandling]int{
+	QStringHandlingUse:    0,
+	QStringHandlingIgnore: 1,
+	QStringHandlingDrop:   2,
+}
+
+// qStringHandlingFromWireValue is the inverse of qStringHandlingWireValues.
+var qStringHandlingFromWireValue = map[int]QStringHandling{
+	0: QStringHandlingUse,
+	1: QStringHandlingIgnore,
+	2: QStringHandlingDrop,
+}
+
+// String returns a string representation of this query string handling.
+func (q QStringHandling) String() string {
+	switch q {
+	case QStringHandlingUse:
+		return "USE"
+	case QStringHandlingIgnore:
+		return "IGNORE"
+	case QStringHandlingDrop:
+		return "DROP"
+	default:
+		return "INVALID"
+	}
+}
+
+// IsValid returns whether this is one of the defined QStringHandling values.
+func (q QStringHandling) IsValid() bool {
+	switch q {
+	case QStringHandlingUse, QStringHandlingIgnore, QStringHandlingDrop:
+		return true
+	default:
+		return false
+	}
+}
+
+// QStringHandlingFromString returns a QStringHandling from its string representation, or QStringHandlingInvalid if the string is not a valid value.
+func QStringHandlingFromString(s string) QStringHandling {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "USE":
+		return QStringHandlingUse
+	case "IGNORE":
+		return QStringHandlingIgnore
+	case "DROP":
+		return QStringHandlingDrop
+	default:
+		return QStringHandlingInvalid
+	}
+}
+
+// QStringHandlingFromInt returns a QStringHandling from its Traffic Ops wire integer representation, or QStringHandlingInvalid if the integer is not a valid value.
+func QStringHandlingFromInt(i int) QStringHandling {
+	if q, ok := qStringHandlingFromWireValue[i]; ok {
+		return q
+	}
+	return QStringHandlingInvalid
+}
+
+// MarshalJSON marshals into a JSON representation. Query string handling is marshaled as its Traffic Ops wire integer form, matching the existing API contract.
+func (q QStringHandling) MarshalJSON() ([]byte, error) {
+	wire, ok := qStringHandlingWireValues[q]
+	if !ok {
+		return nil, errors.New("invalid QStringHandling")
+	}
+	return json.Marshal(wire)
+}
+
+// UnmarshalJSON unmarshals a JSON representation of a QStringHandling, accepting either its integer form (e.g. 1) or its string form (e.g. "IGNORE"), or returns an error if the value is invalid.
+func (q *QStringHandling) UnmarshalJSON(data []byte) error {
+	var i int
+	if err := json.Unmarshal(data, &i); err == nil {
+		*q = QStringHandlingFromInt(i)
+	} else {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return errors.New(string(data) + " is not a valid QStringHandling")
+		}
+		*q = QStringHandlingFromString(s)
+	}
+	if !q.IsValid() {
+		return errors.New(string(data) + " is not a QStringHandling")
+	}
+	return nil
+}
+
+// MaxOriginConnections is the maximum number of concurrent connections a cache tier may open to a Delivery Service's Origin(s). Zero means unlimited.
+type MaxOriginConnections int
+
+// MaxMaxOriginConnections is the largest value permitted for a MaxOriginConnections; configuring more than this is almost certainly a mistake that would overwhelm the Origin.
+const MaxMaxOriginConnections = MaxOriginConnections(100000)
+
+// Validate returns an error if this MaxOriginConnections is not valid: it must be non-negative (0 meaning unlimited) and no greater than MaxMaxOriginConnections.
+func (m MaxOriginConnections) Validate() error {
+	if m < 0 {
+		return fmt.Errorf("max origin connections must not be negative, got %d", m)
+	}
+	if m > MaxMaxOriginConnections {
+		return fmt.Errorf("max origin connections must not exceed %d, got %d", MaxMaxOriginConnections, m)
+	}
+	return nil
+}
+
 type DSMatchType string
 
 const (
@@ -434,3 +956,287 @@ func DSMatchTypeFromString(s string) DSMatchType {
 		return DSMatchTypeInvalid
 	}
 }
+
+// TopologyCacheTier represents a cache's position in a multi-tier Topology. Unlike most enums in this package, it is not a closed set: a flexible Topology may define arbitrary named tiers in addition to the well-known EDGE, MID, ORIGIN and LAST_CACHE tiers.
+type TopologyCacheTier string
+
+const (
+	// TopologyCacheTierEdge is the client-facing tier that serves clients directly.
+	TopologyCacheTierEdge = TopologyCacheTier("EDGE")
+	// TopologyCacheTierMid is an intermediate tier that serves EDGE (or other downstream) caches on a miss.
+	TopologyCacheTierMid = TopologyCacheTier("MID")
+	// TopologyCacheTierOriginShield is a MID-like tier that shields the Origin, ranked above MID and below LAST_CACHE, modeled distinctly from a generic MID so origin shielding can be reasoned about explicitly rather than inferred from position.
+	TopologyCacheTierOriginShield = TopologyCacheTier("ORIGIN_SHIELD")
+	// TopologyCacheTierLastCache is the final cache tier before a request leaves the CDN for the Origin.
+	TopologyCacheTierLastCache = TopologyCacheTier("LAST_CACHE")
+	// TopologyCacheTierOrigin represents the Origin itself, rather than a cache.
+	TopologyCacheTierOrigin = TopologyCacheTier("ORIGIN")
+	// TopologyCacheTierInvalid represents an invalid tier enumeration. Note this is the default construction for a TopologyCacheTier.
+	TopologyCacheTierInvalid = TopologyCacheTier("")
+)
+
+// String returns a string representation of this Topology cache tier. Arbitrary named tiers are returned verbatim, since - unlike most enums in this package - they are valid values and not errors.
+func (t TopologyCacheTier) String() string {
+	if t == TopologyCacheTierInvalid {
+		return "INVALID"
+	}
+	return string(t)
+}
+
+// TopologyCacheTierFromString returns a TopologyCacheTier from its string representation, or TopologyCacheTierInvalid if the string is empty after trimming whitespace. The input is trimmed and upper-cased; any value that is not a well-known tier is accepted in that form as an arbitrary named tier.
+func TopologyCacheTierFromString(s string) TopologyCacheTier {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return TopologyCacheTierInvalid
+	}
+	return TopologyCacheTier(s)
+}
+
+// rank orders well-known tiers from client-facing (low) to Origin-facing (high). Arbitrary named tiers rank alongside MID, since a flexible Topology is free to place them anywhere between EDGE and ORIGIN.
+func (t TopologyCacheTier) rank() int {
+	switch t {
+	case TopologyCacheTierEdge:
+		return 0
+	case TopologyCacheTierOriginShield:
+		return 2
+	case TopologyCacheTierLastCache:
+		return 3
+	case TopologyCacheTierOrigin:
+		return 4
+	case TopologyCacheTierMid:
+		return 1
+	default:
+		return 1
+	}
+}
+
+// IsUpstreamOf returns whether this tier serves the other tier's cache misses, i.e. whether it is further from the client and closer to the Origin in a request's path. The canonical ordering is EDGE -> MID -> ORIGIN_SHIELD -> LAST_CACHE -> ORIGIN, with arbitrary named tiers ranked alongside MID. TopologyCacheTierInvalid is never upstream of, nor has anything upstream of it; it doesn't rank alongside a real tier.
+func (t TopologyCacheTier) IsUpstreamOf(other TopologyCacheTier) bool {
+	if t == TopologyCacheTierInvalid || other == TopologyCacheTierInvalid {
+		return false
+	}
+	return t.rank() > other.rank()
+}
+
+// CanServeClients returns whether caches at this tier may serve requests directly from clients. Only EDGE (and arbitrary named tiers, which a flexible Topology may use as its outermost tier) may serve clients; MID, ORIGIN_SHIELD, LAST_CACHE and ORIGIN do not.
+func (t TopologyCacheTier) CanServeClients() bool {
+	switch t {
+	case TopologyCacheTierMid, TopologyCacheTierOriginShield, TopologyCacheTierLastCache, TopologyCacheTierOrigin:
+		return false
+	case TopologyCacheTierInvalid:
+		return false
+	default:
+		return true
+	}
+}
+
+// CacheRole represents the functional role of a CacheGroup within a CDN - the legacy counterpart to TopologyCacheTier, using Traffic Ops's historical CacheGroup-type naming.
+type CacheRole string
+
+const (
+	// CacheRoleEdge represents an edge-location CacheGroup.
+	CacheRoleEdge = CacheRole("EDGE_LOC")
+	// CacheRoleMid represents a mid-location CacheGroup.
+	CacheRoleMid = CacheRole("MID_LOC")
+	// CacheRoleOrigin represents an Origin-location CacheGroup.
+	CacheRoleOrigin = CacheRole("ORG_LOC")
+	// CacheRoleInvalid represents an invalid CacheGroup role enumeration. Note this is the default construction for a CacheRole.
+	CacheRoleInvalid = CacheRole("")
+)
+
+// String returns a string representation of this CacheGroup role.
+func (r CacheRole) String() string {
+	switch r {
+	case CacheRoleEdge, CacheRoleMid, CacheRoleOrigin:
+		return string(r)
+	default:
+		return "INVALID"
+	}
+}
+
+// CacheRoleFromString returns a CacheRole from its string representation, or CacheRoleInvalid if the string is not a valid role.
+func CacheRoleFromString(s string) CacheRole {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	switch s {
+	case "EDGE_LOC", "EDGE":
+		return CacheRoleEdge
+	case "MID_LOC", "MID":
+		return CacheRoleMid
+	case "ORG_LOC", "ORIGIN":
+		return CacheRoleOrigin
+	default:
+		return CacheRoleInvalid
+	}
+}
+
+// ToTopologyTier converts this CacheRole to its corresponding TopologyCacheTier, mirroring CacheType.ToTopologyTier() for code that deals in the legacy CacheGroup-type naming rather than the newer multi-tier enum. CacheRoleInvalid converts to TopologyCacheTierInvalid.
+func (r CacheRole) ToTopologyTier() TopologyCacheTier {
+	switch r {
+	case CacheRoleEdge:
+		return TopologyCacheTierEdge
+	case CacheRoleMid:
+		return TopologyCacheTierMid
+	case CacheRoleOrigin:
+		return TopologyCacheTierOrigin
+	default:
+		return TopologyCacheTierInvalid
+	}
+}
+
+// IsUpstreamOf returns whether this role serves the other role's cache misses, i.e. is further from the client and closer to the Origin. It defers to TopologyCacheTier's ordering via ToTopologyTier.
+func (r CacheRole) IsUpstreamOf(other CacheRole) bool {
+	return r.ToTopologyTier().IsUpstreamOf(other.ToTopologyTier())
+}
+
+// CanServeClients returns whether CacheGroups with this role may serve requests directly from clients. It defers to TopologyCacheTier.CanServeClients via ToTopologyTier.
+func (r CacheRole) CanServeClients() bool {
+	return r.ToTopologyTier().CanServeClients()
+}
+
+// SteeringTargetType represents how a steering Delivery Service orders and weights its targets.
+type SteeringTargetType string
+
+const (
+	// SteeringTargetTypeWeight selects targets by each target's configured weight, without regard to the client's location.
+	SteeringTargetTypeWeight = SteeringTargetType("STEERING_WEIGHT")
+	// SteeringTargetTypeOrder orders targets by each target's configured order, without regard to the client's location.
+	SteeringTargetTypeOrder = SteeringTargetType("STEERING_ORDER")
+	// SteeringTargetTypeGeoOrder orders targets by configured order, among those nearest the client's location.
+	SteeringTargetTypeGeoOrder = SteeringTargetType("STEERING_GEO_ORDER")
+	// SteeringTargetTypeGeoWeight selects targets by configured weight, among those nearest the client's location.
+	SteeringTargetTypeGeoWeight = SteeringTargetType("STEERING_GEO_WEIGHT")
+	// SteeringTargetTypeInvalid represents an invalid steering target type enumeration. Note this is the default construction for a SteeringTargetType.
+	SteeringTargetTypeInvalid = SteeringTargetType("")
+)
+
+// String returns a string representation of this steering target type.
+func (t SteeringTargetType) String() string {
+	switch t {
+	case SteeringTargetTypeWeight, SteeringTargetTypeOrder, SteeringTargetTypeGeoOrder, SteeringTargetTypeGeoWeight:
+		return string(t)
+	default:
+		return "INVALID"
+	}
+}
+
+// SteeringTargetTypeFromString returns a SteeringTargetType from its string representation, or SteeringTargetTypeInvalid if the string is not a valid type.
+func SteeringTargetTypeFromString(s string) SteeringTargetType {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "STEERING_WEIGHT":
+		return SteeringTargetTypeWeight
+	case "STEERING_ORDER":
+		return SteeringTargetTypeOrder
+	case "STEERING_GEO_ORDER":
+		return SteeringTargetTypeGeoOrder
+	case "STEERING_GEO_WEIGHT":
+		return SteeringTargetTypeGeoWeight
+	default:
+		return SteeringTargetTypeInvalid
+	}
+}
+
+// IsGeo returns whether this steering target type narrows candidates by the client's geographic location before ordering or weighting them.
+func (t SteeringTargetType) IsGeo() bool {
+	return t == SteeringTargetTypeGeoOrder || t == SteeringTargetTypeGeoWeight
+}
+
+// IsWeighted returns whether this steering target type selects among candidates by configured weight, rather than by configured order.
+func (t SteeringTargetType) IsWeighted() bool {
+	return t == SteeringTargetTypeWeight || t == SteeringTargetTypeGeoWeight
+}
+
+// UnmarshalJSON unmarshals a JSON representation of a SteeringTargetType (i.e. a string) or returns an error if the SteeringTargetType is invalid.
+func (t *SteeringTargetType) UnmarshalJSON(data []byte) error {
+	s, err := strconv.Unquote(string(data))
+	if err != nil {
+		return errors.New(string(data) + " JSON not quoted")
+	}
+	*t = SteeringTargetTypeFromString(s)
+	if *t == SteeringTargetTypeInvalid {
+		return errors.New(string(data) + " is not a SteeringTargetType")
+	}
+	return nil
+}
+
+// MarshalJSON marshals into a JSON representation, or returns an error if the SteeringTargetType is invalid - consistent with the other zero-value-is-invalid enums in this package (e.g. DSProtocol), rather than silently emitting "INVALID".
+func (t SteeringTargetType) MarshalJSON() ([]byte, error) {
+	if t == SteeringTargetTypeInvalid {
+		return nil, errors.New("invalid SteeringTargetType")
+	}
+	return json.Marshal(t.String())
+}
+
+// SteeringTarget is a single candidate Delivery Service that a steering Delivery Service may route a client to, along with the weight and order Traffic Ops has configured for it.
+type SteeringTarget struct {
+	DeliveryService DeliveryServiceName
+	Weight          float64
+	Order           int
+	Latitude        float64
+	Longitude       float64
+}
+
+// Geo is a client's resolved geographic location, used to narrow steering targets when a SteeringTargetType is geo-aware.
+type Geo struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// ResolveTarget picks the SteeringTarget that this SteeringTargetType's ordering/weighting rules select from candidates, given the client's resolved location. Weighted types return the candidate with the greatest weight and ordered types the one with the lowest order; no random choice is made, and ties go to the earliest candidate. clientGeo may be nil, in which case geo-aware types fall back to considering all candidates. It returns the zero SteeringTarget if candidates is empty; callers should check RequiresTargets/len(candidates) beforehand.
+func (t SteeringTargetType) ResolveTarget(candidates []SteeringTarget, clientGeo *Geo) SteeringTarget {
+	if len(candidates) == 0 {
+		return SteeringTarget{}
+	}
+	if t.IsGeo() && clientGeo != nil {
+		candidates = nearestSteeringTargets(candidates, *clientGeo)
+	}
+	if t.IsWeighted() {
+		return highestWeightSteeringTarget(candidates)
+	}
+	return lowestOrderSteeringTarget(candidates)
+}
+
+// nearestSteeringTargets returns the subset of candidates with the smallest (squared) distance to geo.
+func nearestSteeringTargets(candidates []SteeringTarget, geo Geo) []SteeringTarget {
+	nearest := []SteeringTarget{candidates[0]}
+	nearestDist := steeringTargetDistance(candidates[0], geo)
+	for _, c := range candidates[1:] {
+		d := steeringTargetDistance(c, geo)
+		switch {
+		case d < nearestDist:
+			nearestDist = d
+			nearest = []SteeringTarget{c}
+		case d == nearestDist:
+			nearest = append(nearest, c)
+		}
+	}
+	return nearest
+}
+
+// steeringTargetDistance returns the squared Euclidean distance between a target and a client location. Squared distance is sufficient since callers only ever compare distances, never use the magnitude itself.
+func steeringTargetDistance(t SteeringTarget, geo Geo) float64 {
+	dLat := t.Latitude - geo.Latitude
+	dLon := t.Longitude - geo.Longitude
+	return dLat*dLat + dLon*dLon
+}
+
+// highestWeightSteeringTarget returns the candidate with the greatest configured weight.
+func highestWeightSteeringTarget(candidates []SteeringTarget) SteeringTarget {
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.Weight > best.Weight {
+			best = c
+		}
+	}
+	return best
+}
+
+// lowestOrderSteeringTarget returns the candidate with the lowest configured order.
+func lowestOrderSteeringTarget(candidates []SteeringTarget) SteeringTarget {
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.Order < best.Order {
+			best = c
+		}
+	}
+	return best
+}
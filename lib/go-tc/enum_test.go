@@ -0,0 +1,275 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+func TestDeepCachingTypeJSONRoundTrip(t *testing.T) {
+	cases := []struct {
+		name string
+		json string
+		want DeepCachingType
+	}{
+		{"null", `null`, DeepCachingTypeUnset},
+		{"empty string", `""`, DeepCachingTypeUnset},
+		{"never", `"NEVER"`, DeepCachingTypeNever},
+		{"always", `"ALWAYS"`, DeepCachingTypeAlways},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got DeepCachingType
+			if err := json.Unmarshal([]byte(c.json), &got); err != nil {
+				t.Fatalf("unmarshaling %s: %v", c.json, err)
+			}
+			if got != c.want {
+				t.Errorf("unmarshaling %s: got %v, want %v", c.json, got, c.want)
+			}
+
+			marshaled, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("marshaling %v: %v", got, err)
+			}
+			var roundTripped DeepCachingType
+			if err := json.Unmarshal(marshaled, &roundTripped); err != nil {
+				t.Fatalf("unmarshaling round-tripped %s: %v", marshaled, err)
+			}
+			if roundTripped != c.want {
+				t.Errorf("round-trip of %s: got %v, want %v", c.json, roundTripped, c.want)
+			}
+		})
+	}
+}
+
+func TestDeepCachingTypeMissingFieldIsUnset(t *testing.T) {
+	type wrapper struct {
+		DCT DeepCachingType `json:"dct,omitempty"`
+	}
+	var w wrapper
+	if err := json.Unmarshal([]byte(`{}`), &w); err != nil {
+		t.Fatalf("unmarshaling: %v", err)
+	}
+	if w.DCT != DeepCachingTypeUnset {
+		t.Errorf("missing field: got %v, want %v", w.DCT, DeepCachingTypeUnset)
+	}
+}
+
+func TestDeepCachingTypeCoalesce(t *testing.T) {
+	if got := DeepCachingTypeUnset.Coalesce(DeepCachingTypeNever); got != DeepCachingTypeNever {
+		t.Errorf("Coalesce on Unset: got %v, want %v", got, DeepCachingTypeNever)
+	}
+	if got := DeepCachingTypeAlways.Coalesce(DeepCachingTypeNever); got != DeepCachingTypeAlways {
+		t.Errorf("Coalesce on explicit value: got %v, want %v", got, DeepCachingTypeAlways)
+	}
+}
+
+func TestDeepCachingTypeFromStringInvalid(t *testing.T) {
+	if got := DeepCachingTypeFromString("bogus"); got != DeepCachingTypeInvalid {
+		t.Errorf("FromString(bogus): got %v, want %v", got, DeepCachingTypeInvalid)
+	}
+}
+
+func TestNeedsCertificates(t *testing.T) {
+	cases := []struct {
+		name string
+		dst  DSType
+		prot DSProtocol
+		want bool
+	}{
+		{"ANY_MAP over HTTPS has no certificate to manage", DSTypeAnyMap, DSProtocolHTTPS, false},
+		{"HTTP type over HTTP needs none", DSTypeHTTP, DSProtocolHTTP, false},
+		{"HTTP type over HTTPS needs one", DSTypeHTTP, DSProtocolHTTPS, true},
+		{"HTTP type over HTTP_TO_HTTPS needs one", DSTypeHTTP, DSProtocolHTTPToHTTPS, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := NeedsCertificates(c.dst, c.prot); got != c.want {
+				t.Errorf("NeedsCertificates(%v, %v): got %v, want %v", c.dst, c.prot, got, c.want)
+			}
+		})
+	}
+}
+
+func TestTLSVersionMarshalInvalid(t *testing.T) {
+	if _, err := TLSVersionInvalid.MarshalJSON(); err == nil {
+		t.Error("marshaling TLSVersionInvalid should error")
+	}
+}
+
+func TestTLSVersionsWarnings(t *testing.T) {
+	if got := TLSVersionsWarnings([]TLSVersion{TLSVersion12, TLSVersion13}); len(got) != 0 {
+		t.Errorf("TLS 1.2 and 1.3 allowed: got warnings %v, want none", got)
+	}
+	if got := TLSVersionsWarnings([]TLSVersion{TLSVersion10}); len(got) == 0 {
+		t.Errorf("allowing TLS 1.0: got no warnings, want at least one")
+	}
+	if got := TLSVersionsWarnings([]TLSVersion{TLSVersion12}); len(got) == 0 {
+		t.Errorf("allowing TLS 1.2 without 1.3: got no warnings, want at least one")
+	}
+	if got := TLSVersionsWarnings([]TLSVersion{TLSVersion10, TLSVersion12}); len(got) < 2 {
+		t.Errorf("allowing TLS 1.0 and 1.2 without 1.1: got %v, want warnings for both the insecure version and the gap", got)
+	}
+}
+
+func TestSliceBlockSizeValidate(t *testing.T) {
+	if err := MinSliceBlockSize.Validate(); err != nil {
+		t.Errorf("MinSliceBlockSize should be valid: %v", err)
+	}
+	if err := MaxSliceBlockSize.Validate(); err != nil {
+		t.Errorf("MaxSliceBlockSize should be valid: %v", err)
+	}
+	if err := SliceBlockSize(0).Validate(); err == nil {
+		t.Error("0 should be invalid: too small")
+	}
+	if err := (MaxSliceBlockSize * 2).Validate(); err == nil {
+		t.Error("2x MaxSliceBlockSize should be invalid: too large")
+	}
+	if err := SliceBlockSize(MinSliceBlockSize + 1).Validate(); err == nil {
+		t.Error("MinSliceBlockSize+1 should be invalid: not a power of two")
+	}
+}
+
+func TestRangeRequestHandlingWireValues(t *testing.T) {
+	cases := []struct {
+		val  RangeRequestHandling
+		wire int
+	}{
+		{RangeRequestHandlingNone, 0},
+		{RangeRequestHandlingBackgroundFetch, 1},
+		{RangeRequestHandlingCacheRangeRequests, 2},
+		{RangeRequestHandlingSlice, 3},
+	}
+	for _, c := range cases {
+		marshaled, err := json.Marshal(c.val)
+		if err != nil {
+			t.Fatalf("marshaling %v: %v", c.val, err)
+		}
+		if string(marshaled) != strconv.Itoa(c.wire) {
+			t.Errorf("marshaling %v: got %s, want %d", c.val, marshaled, c.wire)
+		}
+		if got := RangeRequestHandlingFromInt(c.wire); got != c.val {
+			t.Errorf("RangeRequestHandlingFromInt(%d): got %v, want %v", c.wire, got, c.val)
+		}
+	}
+
+	if _, err := RangeRequestHandlingInvalid.MarshalJSON(); err == nil {
+		t.Error("marshaling RangeRequestHandlingInvalid should error")
+	}
+}
+
+func TestQStringHandlingWireValues(t *testing.T) {
+	cases := []struct {
+		val  QStringHandling
+		wire int
+	}{
+		{QStringHandlingUse, 0},
+		{QStringHandlingIgnore, 1},
+		{QStringHandlingDrop, 2},
+	}
+	for _, c := range cases {
+		marshaled, err := json.Marshal(c.val)
+		if err != nil {
+			t.Fatalf("marshaling %v: %v", c.val, err)
+		}
+		if string(marshaled) != strconv.Itoa(c.wire) {
+			t.Errorf("marshaling %v: got %s, want %d", c.val, marshaled, c.wire)
+		}
+		if got := QStringHandlingFromInt(c.wire); got != c.val {
+			t.Errorf("QStringHandlingFromInt(%d): got %v, want %v", c.wire, got, c.val)
+		}
+	}
+
+	if _, err := QStringHandlingInvalid.MarshalJSON(); err == nil {
+		t.Error("marshaling QStringHandlingInvalid should error")
+	}
+}
+
+func TestDSTypeIsSteering(t *testing.T) {
+	if DSTypeDNSLive.IsSteering() {
+		t.Error("DSTypeDNSLive.IsSteering(): got true, want false")
+	}
+	if !DSTypeSteering.IsSteering() {
+		t.Error("DSTypeSteering.IsSteering(): got false, want true")
+	}
+	if !DSTypeClientSteering.IsSteering() {
+		t.Error("DSTypeClientSteering.IsSteering(): got false, want true")
+	}
+}
+
+func TestSteeringTargetTypeResolveTarget(t *testing.T) {
+	targets := []SteeringTarget{
+		{DeliveryService: "far-low-order-high-weight", Weight: 10, Order: 1, Latitude: 40, Longitude: 40},
+		{DeliveryService: "near-high-order-low-weight", Weight: 1, Order: 2, Latitude: 0, Longitude: 0},
+	}
+	clientGeo := &Geo{Latitude: 0, Longitude: 0}
+
+	if got := SteeringTargetTypeOrder.ResolveTarget(targets, clientGeo); got.DeliveryService != "far-low-order-high-weight" {
+		t.Errorf("STEERING_ORDER: got %v, want lowest order", got.DeliveryService)
+	}
+	if got := SteeringTargetTypeWeight.ResolveTarget(targets, clientGeo); got.DeliveryService != "far-low-order-high-weight" {
+		t.Errorf("STEERING_WEIGHT: got %v, want highest weight", got.DeliveryService)
+	}
+	if got := SteeringTargetTypeGeoOrder.ResolveTarget(targets, clientGeo); got.DeliveryService != "near-high-order-low-weight" {
+		t.Errorf("STEERING_GEO_ORDER: got %v, want nearest target", got.DeliveryService)
+	}
+	if got := (SteeringTargetType("")).ResolveTarget(nil, clientGeo); got != (SteeringTarget{}) {
+		t.Errorf("empty candidates: got %v, want zero value", got)
+	}
+}
+
+func TestSteeringTargetTypeMarshalInvalid(t *testing.T) {
+	if _, err := SteeringTargetTypeInvalid.MarshalJSON(); err == nil {
+		t.Error("marshaling SteeringTargetTypeInvalid should error")
+	}
+}
+
+func TestCacheRoleTopologyTier(t *testing.T) {
+	if !CacheRoleOrigin.IsUpstreamOf(CacheRoleMid) {
+		t.Error("CacheRoleOrigin.IsUpstreamOf(CacheRoleMid): got false, want true")
+	}
+	if CacheRoleEdge.IsUpstreamOf(CacheRoleMid) {
+		t.Error("CacheRoleEdge.IsUpstreamOf(CacheRoleMid): got true, want false")
+	}
+	if !CacheRoleEdge.CanServeClients() {
+		t.Error("CacheRoleEdge.CanServeClients(): got false, want true")
+	}
+	if CacheRoleMid.CanServeClients() {
+		t.Error("CacheRoleMid.CanServeClients(): got true, want false")
+	}
+	if got := CacheRoleEdge.ToTopologyTier(); got != TopologyCacheTierEdge {
+		t.Errorf("CacheRoleEdge.ToTopologyTier(): got %v, want %v", got, TopologyCacheTierEdge)
+	}
+}
+
+func TestTopologyCacheTierIsUpstreamOfInvalid(t *testing.T) {
+	if TopologyCacheTierInvalid.IsUpstreamOf(TopologyCacheTierEdge) {
+		t.Error("TopologyCacheTierInvalid.IsUpstreamOf(Edge): got true, want false")
+	}
+	if TopologyCacheTierEdge.IsUpstreamOf(TopologyCacheTierInvalid) {
+		t.Error("TopologyCacheTierEdge.IsUpstreamOf(Invalid): got true, want false")
+	}
+	if CacheRoleInvalid.IsUpstreamOf(CacheRoleEdge) {
+		t.Error("CacheRoleInvalid.IsUpstreamOf(CacheRoleEdge): got true, want false")
+	}
+}